@@ -0,0 +1,55 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package atomicutil provides type-safe wrappers around sync/atomic
+// operations for types that are not natively supported, such as
+// time.Duration.
+//
+// TODO(settings): add analogous wrappers for int64/float64/bool once the
+// Int/Float/Bool setting types are migrated to this package; only
+// AtomicDuration exists so far.
+package atomicutil
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// AtomicDuration is a wrapper around an int64 that packs and unpacks a
+// time.Duration, so that callers don't need to cast through int64 at every
+// call site.
+type AtomicDuration struct {
+	v int64
+}
+
+// Load atomically loads and returns the stored duration.
+func (d *AtomicDuration) Load() time.Duration {
+	return time.Duration(atomic.LoadInt64(&d.v))
+}
+
+// Store atomically stores v.
+func (d *AtomicDuration) Store(v time.Duration) {
+	atomic.StoreInt64(&d.v, int64(v))
+}
+
+// Swap atomically stores v and returns the previously stored duration.
+func (d *AtomicDuration) Swap(v time.Duration) time.Duration {
+	return time.Duration(atomic.SwapInt64(&d.v, int64(v)))
+}
+
+// CompareAndSwap atomically stores newDuration if the stored value equals
+// old, and reports whether it did so.
+func (d *AtomicDuration) CompareAndSwap(old, newDuration time.Duration) bool {
+	return atomic.CompareAndSwapInt64(&d.v, int64(old), int64(newDuration))
+}
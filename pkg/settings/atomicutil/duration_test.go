@@ -0,0 +1,49 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package atomicutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAtomicDuration(t *testing.T) {
+	var d AtomicDuration
+	if got := d.Load(); got != 0 {
+		t.Fatalf("zero value Load() = %s, want 0", got)
+	}
+
+	d.Store(time.Second)
+	if got := d.Load(); got != time.Second {
+		t.Fatalf("Load() after Store(1s) = %s, want 1s", got)
+	}
+
+	if old := d.Swap(time.Minute); old != time.Second {
+		t.Fatalf("Swap(1m) returned %s, want 1s", old)
+	}
+	if got := d.Load(); got != time.Minute {
+		t.Fatalf("Load() after Swap(1m) = %s, want 1m", got)
+	}
+
+	if d.CompareAndSwap(time.Second, time.Hour) {
+		t.Fatalf("CompareAndSwap(1s, 1h) succeeded despite stale old value")
+	}
+	if !d.CompareAndSwap(time.Minute, time.Hour) {
+		t.Fatalf("CompareAndSwap(1m, 1h) failed despite matching old value")
+	}
+	if got := d.Load(); got != time.Hour {
+		t.Fatalf("Load() after CompareAndSwap(1m, 1h) = %s, want 1h", got)
+	}
+}
@@ -15,27 +15,60 @@
 package settings
 
 import (
-	"sync/atomic"
+	"math"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/settings/atomicutil"
+	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 )
 
+// minDuration and maxDuration bound the range reported by Min and Max for
+// settings that were not registered with explicit bounds.
+const (
+	minDuration = time.Duration(math.MinInt64)
+	maxDuration = time.Duration(math.MaxInt64)
+)
+
 // DurationSetting is the interface of a setting variable that will be
 // updated automatically when the corresponding cluster-wide setting
 // of type "duration" is updated.
 type DurationSetting struct {
 	common
 	defaultValue time.Duration
-	v            int64
+	v            atomicutil.AtomicDuration
 	validateFn   func(time.Duration) error
+	min, max     time.Duration
 }
 
 var _ Setting = &DurationSetting{}
 
 // Get retrieves the duration value in the setting.
 func (d *DurationSetting) Get() time.Duration {
-	return time.Duration(atomic.LoadInt64(&d.v))
+	return d.v.Load()
+}
+
+// Default returns the default value for the setting.
+//
+// TODO(settings): add the analogous Default/Min/Max accessors to
+// IntSetting, FloatSetting and BoolSetting once those types exist in this
+// tree; only DurationSetting is covered so far.
+func (d *DurationSetting) Default() time.Duration {
+	return d.defaultValue
+}
+
+// Min returns the minimum value allowed for the setting, or the smallest
+// representable duration if the setting was not registered with an
+// explicit lower bound.
+func (d *DurationSetting) Min() time.Duration {
+	return d.min
+}
+
+// Max returns the maximum value allowed for the setting, or the largest
+// representable duration if the setting was not registered with an
+// explicit upper bound.
+func (d *DurationSetting) Max() time.Duration {
+	return d.max
 }
 
 func (d *DurationSetting) String() string {
@@ -61,7 +94,7 @@ func (d *DurationSetting) set(v time.Duration) error {
 	if err := d.Validate(v); err != nil {
 		return err
 	}
-	if v := int64(v); atomic.SwapInt64(&d.v, v) != v {
+	if d.v.Swap(v) != v {
 		d.changed()
 	}
 	return nil
@@ -73,6 +106,39 @@ func (d *DurationSetting) setToDefault() {
 	}
 }
 
+// Marshal encodes the current value of the setting as a
+// google.protobuf.Duration message, for use in contexts (e.g. BACKUP of
+// cluster settings, gossip) that need a stable, self-describing wire format
+// instead of the free-form strings produced by String().
+func (d *DurationSetting) Marshal() ([]byte, error) {
+	return types.DurationProto(d.Get()).Marshal()
+}
+
+// Unmarshal decodes a google.protobuf.Duration message produced by Marshal
+// and applies it to the setting.
+func (d *DurationSetting) Unmarshal(data []byte) error {
+	var pb types.Duration
+	if err := pb.Unmarshal(data); err != nil {
+		return err
+	}
+	dur, err := types.DurationFromProto(&pb)
+	if err != nil {
+		return err
+	}
+	return d.set(dur)
+}
+
+// SetFromString parses a duration using the extended grammar supported by
+// ParseDuration (which accepts "d" and "w" units in addition to what
+// time.ParseDuration understands) and applies the result to the setting.
+func (d *DurationSetting) SetFromString(v string) error {
+	dur, err := ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	return d.set(dur)
+}
+
 // RegisterDurationSetting defines a new setting with type duration.
 func RegisterDurationSetting(key, desc string, defaultValue time.Duration) *DurationSetting {
 	return RegisterValidatedDurationSetting(key, desc, defaultValue, nil)
@@ -82,12 +148,44 @@ func RegisterDurationSetting(key, desc string, defaultValue time.Duration) *Dura
 func RegisterNonNegativeDurationSetting(
 	key, desc string, defaultValue time.Duration,
 ) *DurationSetting {
-	return RegisterValidatedDurationSetting(key, desc, defaultValue, func(v time.Duration) error {
+	setting := RegisterValidatedDurationSetting(key, desc, defaultValue, func(v time.Duration) error {
 		if v < 0 {
 			return errors.Errorf("cannot set %s to a negative duration: %s", key, v)
 		}
 		return nil
 	})
+	setting.min, setting.max = 0, maxDuration
+	return setting
+}
+
+// RegisterPositiveDurationSetting defines a new setting with type duration
+// which disallows zero and negative values.
+func RegisterPositiveDurationSetting(
+	key, desc string, defaultValue time.Duration,
+) *DurationSetting {
+	setting := RegisterValidatedDurationSetting(key, desc, defaultValue, func(v time.Duration) error {
+		if v <= 0 {
+			return errors.Errorf("cannot set %s to a non-positive duration: %s", key, v)
+		}
+		return nil
+	})
+	setting.min, setting.max = time.Nanosecond, maxDuration
+	return setting
+}
+
+// RegisterBoundedDurationSetting defines a new setting with type duration
+// that is restricted to the range [min, max].
+func RegisterBoundedDurationSetting(
+	key, desc string, defaultValue, min, max time.Duration,
+) *DurationSetting {
+	setting := RegisterValidatedDurationSetting(key, desc, defaultValue, func(v time.Duration) error {
+		if v < min || v > max {
+			return errors.Errorf("cannot set %s to %s: must be in [%s, %s]", key, v, min, max)
+		}
+		return nil
+	})
+	setting.min, setting.max = min, max
+	return setting
 }
 
 // RegisterValidatedDurationSetting defines a new setting with type duration.
@@ -102,6 +200,8 @@ func RegisterValidatedDurationSetting(
 	setting := &DurationSetting{
 		defaultValue: defaultValue,
 		validateFn:   validateFn,
+		min:          minDuration,
+		max:          maxDuration,
 	}
 	register(key, desc, setting)
 	return setting
@@ -111,7 +211,7 @@ func RegisterValidatedDurationSetting(
 // See TestingSetBool for more details.
 func TestingSetDuration(s **DurationSetting, v time.Duration) func() {
 	saved := *s
-	*s = &DurationSetting{v: int64(v)}
+	*s = TestingDuration(v)
 	return func() {
 		*s = saved
 	}
@@ -120,7 +220,9 @@ func TestingSetDuration(s **DurationSetting, v time.Duration) func() {
 // TestingDuration returns a one off, unregistered duration setting for test use
 // only.
 func TestingDuration(v time.Duration) *DurationSetting {
-	return &DurationSetting{v: int64(v)}
+	d := &DurationSetting{min: minDuration, max: maxDuration}
+	d.v.Store(v)
+	return d
 }
 
 // OnChange registers a callback to be called when the setting changes.
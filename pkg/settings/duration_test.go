@@ -0,0 +1,91 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package settings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationSettingBounds(t *testing.T) {
+	unbounded := RegisterDurationSetting("test.duration.unbounded", "desc", time.Second)
+	if got, want := unbounded.Min(), minDuration; got != want {
+		t.Errorf("Min() = %s, want %s", got, want)
+	}
+	if got, want := unbounded.Max(), maxDuration; got != want {
+		t.Errorf("Max() = %s, want %s", got, want)
+	}
+	if got, want := unbounded.Default(), time.Second; got != want {
+		t.Errorf("Default() = %s, want %s", got, want)
+	}
+
+	bounded := RegisterBoundedDurationSetting("test.duration.bounded", "desc", time.Minute, time.Second, time.Hour)
+	if got, want := bounded.Min(), time.Second; got != want {
+		t.Errorf("Min() = %s, want %s", got, want)
+	}
+	if got, want := bounded.Max(), time.Hour; got != want {
+		t.Errorf("Max() = %s, want %s", got, want)
+	}
+	if err := bounded.set(time.Millisecond); err == nil {
+		t.Errorf("set(1ms) below Min() succeeded, want error")
+	}
+	if err := bounded.set(2 * time.Hour); err == nil {
+		t.Errorf("set(2h) above Max() succeeded, want error")
+	}
+
+	nonNegative := RegisterNonNegativeDurationSetting("test.duration.nonnegative", "desc", 0)
+	if err := nonNegative.set(-time.Second); err == nil {
+		t.Errorf("set(-1s) on non-negative setting succeeded, want error")
+	} else if got, want := err.Error(), "cannot set test.duration.nonnegative to a negative duration: -1s"; got != want {
+		t.Errorf("set(-1s) error = %q, want %q", got, want)
+	}
+	if got, want := nonNegative.Min(), time.Duration(0); got != want {
+		t.Errorf("Min() = %s, want %s", got, want)
+	}
+
+	positive := RegisterPositiveDurationSetting("test.duration.positive", "desc", time.Second)
+	if err := positive.set(0); err == nil {
+		t.Errorf("set(0) on positive setting succeeded, want error")
+	}
+	if got, want := positive.Min(), time.Nanosecond; got != want {
+		t.Errorf("Min() = %s, want %s", got, want)
+	}
+}
+
+func TestTestingDurationBounds(t *testing.T) {
+	d := TestingDuration(time.Second)
+	if got, want := d.Min(), minDuration; got != want {
+		t.Errorf("Min() = %s, want %s", got, want)
+	}
+	if got, want := d.Max(), maxDuration; got != want {
+		t.Errorf("Max() = %s, want %s", got, want)
+	}
+}
+
+func TestDurationSettingMarshalRoundTrip(t *testing.T) {
+	orig := TestingDuration(90 * time.Minute)
+	data, err := orig.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+
+	got := TestingDuration(0)
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal() failed: %v", err)
+	}
+	if got.Get() != orig.Get() {
+		t.Errorf("round trip through Marshal/Unmarshal = %s, want %s", got.Get(), orig.Get())
+	}
+}
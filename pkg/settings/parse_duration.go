@@ -0,0 +1,131 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package settings
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// extendedDurationUnitRE matches a single `<number><unit>` term of the
+// extended duration grammar, e.g. "1w", "2d", "3.5h".
+var extendedDurationUnitRE = regexp.MustCompile(`(\d+(?:\.\d+)?)([a-zµ]+)`)
+
+// extendedDurationRE matches a full extended duration expression: an
+// optional sign followed by one or more `<number><unit>` terms with no
+// intervening characters, e.g. "1w2d3h".
+var extendedDurationRE = regexp.MustCompile(`^([-+]?)(?:` + extendedDurationUnitRE.String() + `)+$`)
+
+// ParseDuration parses a duration string, accepting the units understood by
+// time.ParseDuration ("ns", "us", "µs", "ms", "s", "m", "h") plus the
+// extended units "d" (day, 24h) and "w" (week, 7d), which time.ParseDuration
+// does not support. Compound expressions like "1w2d3h" are allowed. Any
+// string of the form `[sign]<number><unit>...` is parsed term by term, with
+// terms in standard units each handed to time.ParseDuration; strings of any
+// other form are passed to time.ParseDuration whole.
+func ParseDuration(s string) (time.Duration, error) {
+	if !extendedDurationRE.MatchString(s) {
+		return time.ParseDuration(s)
+	}
+
+	neg := false
+	rest := s
+	if rest[0] == '-' || rest[0] == '+' {
+		neg = rest[0] == '-'
+		rest = rest[1:]
+	}
+
+	var totalNanos int64
+	for _, m := range extendedDurationUnitRE.FindAllStringSubmatch(rest, -1) {
+		numStr, unit := m[1], m[2]
+		var termNanos int64
+		switch unit {
+		case "d":
+			n, err := parseUnitNanos(numStr, 24*time.Hour)
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid duration %q", s)
+			}
+			termNanos = n
+		case "w":
+			n, err := parseUnitNanos(numStr, 7*24*time.Hour)
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid duration %q", s)
+			}
+			termNanos = n
+		default:
+			term, err := time.ParseDuration(numStr + unit)
+			if err != nil {
+				return 0, errors.Wrapf(err, "invalid duration %q", s)
+			}
+			termNanos = int64(term)
+		}
+
+		// Bounds-check the addition in integer arithmetic: summing floats
+		// near the int64 boundary loses enough precision that a genuine
+		// overflow can silently round down to exactly math.MaxInt64 and
+		// slip past a float comparison.
+		switch {
+		case termNanos > 0 && totalNanos > math.MaxInt64-termNanos:
+			return 0, errors.Errorf("duration %q overflows time.Duration", s)
+		case termNanos < 0 && totalNanos < math.MinInt64-termNanos:
+			return 0, errors.Errorf("duration %q overflows time.Duration", s)
+		}
+		totalNanos += termNanos
+	}
+
+	total := time.Duration(totalNanos)
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// parseUnitNanos converts a "d"/"w"-style magnitude (e.g. "2" or "1.5") into
+// nanoseconds given the nanosecond value of one unit. Integer magnitudes are
+// multiplied exactly, with an overflow check, since that's the common case
+// and the one most exposed to overflow; fractional magnitudes go through
+// float64, which cannot represent every int64 exactly but only arises for
+// values with a decimal point.
+func parseUnitNanos(numStr string, unit time.Duration) (int64, error) {
+	if !strings.Contains(numStr, ".") {
+		n, err := strconv.ParseInt(numStr, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			return 0, nil
+		}
+		result := n * int64(unit)
+		if result/int64(unit) != n {
+			return 0, errors.Errorf("magnitude %s overflows time.Duration", numStr)
+		}
+		return result, nil
+	}
+
+	f, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, err
+	}
+	termF := f * float64(unit)
+	if termF > float64(math.MaxInt64) || termF < float64(math.MinInt64) {
+		return 0, errors.Errorf("magnitude %s overflows time.Duration", numStr)
+	}
+	return int64(termF), nil
+}
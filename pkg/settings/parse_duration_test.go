@@ -0,0 +1,64 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package settings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	testCases := []struct {
+		input  string
+		expect time.Duration
+	}{
+		{"1h", time.Hour},
+		{"1h30m", time.Hour + 30*time.Minute},
+		{"1d", 24 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+		{"1w2d3h", 7*24*time.Hour + 2*24*time.Hour + 3*time.Hour},
+		{"-1d", -24 * time.Hour},
+		{"+2d", 48 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+	}
+	for _, tc := range testCases {
+		got, err := ParseDuration(tc.input)
+		if err != nil {
+			t.Errorf("ParseDuration(%q): unexpected error: %v", tc.input, err)
+			continue
+		}
+		if got != tc.expect {
+			t.Errorf("ParseDuration(%q) = %s, want %s", tc.input, got, tc.expect)
+		}
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	testCases := []string{
+		"",
+		"garbage",
+		"1x",
+		"999999999999999d",
+		// math.MaxInt64 + 500ns: the "d" term alone is within range, but
+		// summing it with the exact "ns" term overflows by a small margin
+		// that float64 accumulation near the int64 boundary can round away.
+		"106751d85636854776307ns",
+	}
+	for _, input := range testCases {
+		if _, err := ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q): expected error, got none", input)
+		}
+	}
+}